@@ -23,6 +23,48 @@ var ObjectPrefixFlag = cli.StringFlag{
 	Usage: "S3 object prefix where the query result is stored.",
 }
 
+var OutputFlag = cli.StringFlag{
+	Name: "output",
+	Value: "table",
+	Usage: "Result output format. One of `csv`, `json`, `tsv`, or `table`.",
+}
+
+var PagerFlag = cli.StringFlag{
+	Name: "pager",
+	EnvVar: "PAGER",
+	Usage: "Pipe results through this command for interactive viewing.",
+}
+
+var WorkgroupFlag = cli.StringFlag{
+	Name: "workgroup",
+	EnvVar: "AQ_WORKGROUP",
+	Usage: "Athena workgroup to run the query under.",
+}
+
+var EncryptionFlag = cli.StringFlag{
+	Name: "encryption",
+	Usage: "Encrypt query results. One of `SSE_S3`, `SSE_KMS`, `CSE_KMS`.",
+}
+
+var KmsKeyFlag = cli.StringFlag{
+	Name: "kms-key",
+	Usage: "KMS key ID to use with --encryption SSE_KMS or CSE_KMS.",
+}
+
+var ResultReuseMaxAgeFlag = cli.Int64Flag{
+	Name: "result-reuse-max-age",
+	Usage: "Reuse a previous query's results if they are no older than this many minutes.",
+}
+
+func validateQueryExecutionFlags(c *cli.Context) error {
+	switch c.String("encryption") {
+	case "", "SSE_S3", "SSE_KMS", "CSE_KMS":
+	default:
+		return cli.NewExitError("encryption must be one of `SSE_S3`, `SSE_KMS`, `CSE_KMS`.", 1)
+	}
+	return nil
+}
+
 var Commands = []cli.Command{
 	{
 		Name:   "query",
@@ -32,11 +74,21 @@ var Commands = []cli.Command{
 		Flags: []cli.Flag{
 			BucketFlag,
 			ObjectPrefixFlag,
+			WorkgroupFlag,
+			EncryptionFlag,
+			KmsKeyFlag,
+			ResultReuseMaxAgeFlag,
 			cli.IntFlag{
 				Name: "timeout, t",
 				Value: 0,
 				Usage: "Wait for execution of the query for this number of seconds. If this is set to 0, timeout is disabled.",
 			},
+			cli.Int64Flag{
+				Name: "cost-estimate",
+				Usage: "Refuse to run if the query's projected scanned bytes (from history) exceeds this many bytes.",
+			},
+			OutputFlag,
+			PagerFlag,
 		},
 		Before: func(c *cli.Context) error {
 			if c.NArg() == 0 {
@@ -45,9 +97,125 @@ var Commands = []cli.Command{
 			if c.String("bucket") == "" {
 				return cli.NewExitError("bucket must be specified.", 1)
 			}
+			if err := validateQueryExecutionFlags(c); err != nil {
+				return err
+			}
+			if err := validateOutputFormat(c); err != nil {
+				return err
+			}
 			return nil
 		},
 	},
+	{
+		Name:   "history",
+		Usage:  "List and rerun past query executions",
+		Action: cmd.History,
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name: "limit, n",
+				Value: 50,
+				Usage: "Maximum number of entries to show.",
+			},
+			cli.StringFlag{
+				Name: "since",
+				Usage: "Only show entries on or after this date, in YYYY-MM-DD format.",
+			},
+			cli.BoolFlag{
+				Name: "failed-only",
+				Usage: "Only show entries that failed.",
+			},
+		},
+		Subcommands: []cli.Command{
+			{
+				Name:   "rerun",
+				Usage:  "Re-execute a past query by its QueryExecutionId",
+				Action: cmd.HistoryRerun,
+				ArgsUsage:   "QUERY_EXECUTION_ID",
+				Flags: []cli.Flag{
+					BucketFlag,
+					ObjectPrefixFlag,
+					WorkgroupFlag,
+					EncryptionFlag,
+					KmsKeyFlag,
+					ResultReuseMaxAgeFlag,
+					cli.IntFlag{
+						Name: "timeout, t",
+						Value: 0,
+						Usage: "Wait for execution of the query for this number of seconds. If this is set to 0, timeout is disabled.",
+					},
+					OutputFlag,
+					PagerFlag,
+				},
+				Before: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return cli.NewExitError("QUERY_EXECUTION_ID must be specified.", 1)
+					}
+					if c.String("bucket") == "" {
+						return cli.NewExitError("bucket must be specified.", 1)
+					}
+					if err := validateQueryExecutionFlags(c); err != nil {
+						return err
+					}
+					return validateOutputFormat(c)
+				},
+			},
+		},
+	},
+	{
+		Name:   "saved",
+		Usage:  "Manage and run named, parameterized queries",
+		Subcommands: []cli.Command{
+			{
+				Name:   "add",
+				Usage:  "Save a named query",
+				Action: cmd.SavedAdd,
+				ArgsUsage:   "NAME SQL",
+				Before: func(c *cli.Context) error {
+					if c.NArg() < 2 {
+						return cli.NewExitError("NAME and SQL must be specified.", 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:   "run",
+				Usage:  "Run a saved query, substituting --param k=v into its template",
+				Action: cmd.SavedRun,
+				ArgsUsage:   "NAME",
+				Flags: []cli.Flag{
+					BucketFlag,
+					ObjectPrefixFlag,
+					WorkgroupFlag,
+					EncryptionFlag,
+					KmsKeyFlag,
+					ResultReuseMaxAgeFlag,
+					cli.IntFlag{
+						Name: "timeout, t",
+						Value: 0,
+						Usage: "Wait for execution of the query for this number of seconds. If this is set to 0, timeout is disabled.",
+					},
+					cli.StringSliceFlag{
+						Name: "param",
+						Usage: "Parameter substituted into the saved query's `{{.key}}` placeholders, as key=value.",
+					},
+					OutputFlag,
+					PagerFlag,
+				},
+				Before: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return cli.NewExitError("NAME must be specified.", 1)
+					}
+					if c.String("bucket") == "" {
+						return cli.NewExitError("bucket must be specified.", 1)
+					}
+					if err := validateQueryExecutionFlags(c); err != nil {
+						return err
+					}
+					return validateOutputFormat(c)
+				},
+			},
+		},
+	},
 	{
 		Name:   "ls",
 		Usage:  "Show databases or tables in specified database",
@@ -56,11 +224,18 @@ var Commands = []cli.Command{
 		Flags: []cli.Flag{
 			BucketFlag,
 			ObjectPrefixFlag,
+			WorkgroupFlag,
+			EncryptionFlag,
+			KmsKeyFlag,
+			ResultReuseMaxAgeFlag,
 		},
 		Before: func(c *cli.Context) error {
 			if c.String("bucket") == "" {
 				return cli.NewExitError("bucket must be specified.", 1)
 			}
+			if err := validateQueryExecutionFlags(c); err != nil {
+				return err
+			}
 			return nil
 		},
 	},
@@ -72,6 +247,10 @@ var Commands = []cli.Command{
 		Flags: []cli.Flag{
 			BucketFlag,
 			ObjectPrefixFlag,
+			WorkgroupFlag,
+			EncryptionFlag,
+			KmsKeyFlag,
+			ResultReuseMaxAgeFlag,
 			cli.IntFlag{
 				Name: "max_rows, n",
 				Value: 100,
@@ -82,6 +261,9 @@ var Commands = []cli.Command{
 			if c.String("bucket") == "" {
 				return cli.NewExitError("bucket must be specified.", 1)
 			}
+			if err := validateQueryExecutionFlags(c); err != nil {
+				return err
+			}
 			if c.NArg() == 0 {
 				return cli.NewExitError("DATABASE and TABLE must be specified.", 1)
 			}
@@ -99,11 +281,18 @@ var Commands = []cli.Command{
 		Flags: []cli.Flag{
 			BucketFlag,
 			ObjectPrefixFlag,
+			WorkgroupFlag,
+			EncryptionFlag,
+			KmsKeyFlag,
+			ResultReuseMaxAgeFlag,
 		},
 		Before: func(c *cli.Context) error {
 			if c.String("bucket") == "" {
 				return cli.NewExitError("bucket must be specified.", 1)
 			}
+			if err := validateQueryExecutionFlags(c); err != nil {
+				return err
+			}
 			if c.NArg() == 0 {
 				return cli.NewExitError("DATABASE must be specified.", 1)
 			}
@@ -121,6 +310,10 @@ var Commands = []cli.Command{
 		Flags: []cli.Flag{
 			BucketFlag,
 			ObjectPrefixFlag,
+			WorkgroupFlag,
+			EncryptionFlag,
+			KmsKeyFlag,
+			ResultReuseMaxAgeFlag,
 			cli.BoolFlag{
 				Name: "force, f",
 				Usage: "Skip confirmation if this is set.",
@@ -130,6 +323,9 @@ var Commands = []cli.Command{
 			if c.String("bucket") == "" {
 				return cli.NewExitError("bucket must be specified.", 1)
 			}
+			if err := validateQueryExecutionFlags(c); err != nil {
+				return err
+			}
 			if c.NArg() == 0 {
 				return cli.NewExitError("NAME must be specified.", 1)
 			}
@@ -147,6 +343,105 @@ var Commands = []cli.Command{
 			return nil
 		},
 	},
+	{
+		Name:   "mb",
+		Usage:  "Create the S3 bucket used to store query results",
+		Action: cmd.Mb,
+		ArgsUsage:   "BUCKET",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name: "region",
+				Usage: "S3 region to create the bucket in.",
+			},
+			cli.StringFlag{
+				Name: "acl",
+				Usage: "Canned ACL to apply to the bucket.",
+			},
+			cli.BoolFlag{
+				Name: "versioning",
+				Usage: "Enable versioning on the bucket.",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return cli.NewExitError("BUCKET must be specified.", 1)
+			}
+			return nil
+		},
+	},
+	{
+		Name:   "rb",
+		Usage:  "Empty and delete the S3 bucket used to store query results",
+		Action: cmd.Rb,
+		ArgsUsage:   "BUCKET",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name: "force, f",
+				Usage: "Skip confirmation if this is set.",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return cli.NewExitError("BUCKET must be specified.", 1)
+			}
+
+			var answer bool
+			if c.Bool("force") {
+				answer = true
+			} else {
+				answer, _ = prompt.Ask("Would you remove " + c.Args().First())
+			}
+			if !answer {
+				return cli.NewExitError("Canceled.", 1)
+			}
+
+			return nil
+		},
+	},
+	{
+		Name:   "show",
+		Usage:  "Show table schema, partitions, and storage metadata",
+		Action: cmd.Show,
+		ArgsUsage:   "DATABASE.TABLE",
+		Flags: []cli.Flag{
+			BucketFlag,
+			ObjectPrefixFlag,
+			WorkgroupFlag,
+			EncryptionFlag,
+			KmsKeyFlag,
+			ResultReuseMaxAgeFlag,
+			cli.StringFlag{
+				Name: "format, f",
+				Value: "table",
+				Usage: "Output format. One of `table`, `json`, or `yaml`.",
+			},
+			cli.IntFlag{
+				Name: "partitions_limit",
+				Value: 100,
+				Usage: "Maximum number of partitions to list.",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if c.String("bucket") == "" {
+				return cli.NewExitError("bucket must be specified.", 1)
+			}
+			if err := validateQueryExecutionFlags(c); err != nil {
+				return err
+			}
+			if c.NArg() == 0 {
+				return cli.NewExitError("DATABASE and TABLE must be specified.", 1)
+			}
+			if len(strings.Split(c.Args().First(), ".")) != 2 {
+				return cli.NewExitError("[DATABASE].[TABLE] must contain `.`.", 1)
+			}
+			switch c.String("format") {
+			case "table", "json", "yaml":
+			default:
+				return cli.NewExitError("format must be one of `table`, `json`, `yaml`.", 1)
+			}
+			return nil
+		},
+	},
 	{
 		Name:   "load",
 		Usage:  "Create table and load data",
@@ -155,30 +450,173 @@ var Commands = []cli.Command{
 		Flags: []cli.Flag{
 			BucketFlag,
 			ObjectPrefixFlag,
+			WorkgroupFlag,
+			EncryptionFlag,
+			KmsKeyFlag,
+			ResultReuseMaxAgeFlag,
 			cli.StringFlag{
 				Name: "source_format, s",
 				Value: "NEWLINE_DELIMITED_JSON",
-				Usage: "Specify source file data format. Now aq support only NEWLINE_DELIMITED_JSON.",
+				Usage: "Specify source file data format. One of NEWLINE_DELIMITED_JSON, CSV, TSV, PARQUET, ORC, AVRO.",
 			},
 			cli.StringFlag{
 				Name: "partitioning, p",
 				Value: "",
 				Usage: "Specify partition key and type. ex. key1:type1,key2:type2,...",
 			},
+			cli.StringFlag{
+				Name: "field_delimiter",
+				Usage: "Field delimiter used by CSV/TSV sources. Defaults to `,` for CSV and tab for TSV.",
+			},
+			cli.IntFlag{
+				Name: "skip_header_rows",
+				Usage: "Number of leading rows to skip in CSV/TSV sources.",
+			},
+			cli.StringFlag{
+				Name: "quote_char",
+				Usage: "Quote character used by CSV/TSV sources. Defaults to `\"`.",
+			},
+			cli.StringFlag{
+				Name: "compression",
+				Usage: "Compression codec of the source data. One of gzip, snappy, zstd.",
+			},
 		},
 		Before: func(c *cli.Context) error {
 			if c.String("bucket") == "" {
 				return cli.NewExitError("bucket must be specified.", 1)
 			}
+			if err := validateQueryExecutionFlags(c); err != nil {
+				return err
+			}
+			if len(strings.Split(c.Args().First(), ".")) != 2 {
+				return cli.NewExitError("[DATABASE].[TABLE] must contain `.`.", 1)
+			}
 			if !strings.HasPrefix(c.Args().Get(1), "s3://") {
 				return cli.NewExitError("`SOURCE` must start with 's3://'", 1)
 			}
-			if c.String("source_format") != "NEWLINE_DELIMITED_JSON" {
-				return cli.NewExitError("Now aq support only NEWLINE_DELIMITED_JSON.", 1)
+			switch strings.ToUpper(c.String("source_format")) {
+			case "NEWLINE_DELIMITED_JSON", "CSV", "TSV", "PARQUET", "ORC", "AVRO":
+			default:
+				return cli.NewExitError("source_format must be one of NEWLINE_DELIMITED_JSON, CSV, TSV, PARQUET, ORC, AVRO.", 1)
+			}
+			switch c.String("compression") {
+			case "", "gzip", "snappy", "zstd":
+			default:
+				return cli.NewExitError("compression must be one of gzip, snappy, zstd.", 1)
 			}
 			return nil
 		},
 	},
+	{
+		Name:   "watch",
+		Usage:  "Continuously register new S3 objects as partitions on a table",
+		Action: cmd.Watch,
+		ArgsUsage:   "DATABASE.TABLE",
+		Flags: []cli.Flag{
+			BucketFlag,
+			ObjectPrefixFlag,
+			WorkgroupFlag,
+			EncryptionFlag,
+			KmsKeyFlag,
+			ResultReuseMaxAgeFlag,
+			cli.StringFlag{
+				Name: "sqs-queue-url",
+				Usage: "SQS queue URL receiving S3 ObjectCreated notifications.",
+			},
+			cli.StringFlag{
+				Name: "polling-method",
+				Value: "sqs",
+				Usage: "How to discover new objects. One of `sqs` or `list`.",
+			},
+			cli.StringFlag{
+				Name: "partition-pattern",
+				Usage: "Template over the S3 key used to derive partition values. ex. year={YYYY}/month={MM}/day={DD}",
+			},
+			cli.IntFlag{
+				Name: "batch-size",
+				Value: 100,
+				Usage: "Maximum number of keys to group into one ADD PARTITION statement.",
+			},
+			cli.Int64Flag{
+				Name: "visibility-timeout",
+				Value: 30,
+				Usage: "SQS message visibility timeout, in seconds.",
+			},
+			cli.DurationFlag{
+				Name: "poll-interval",
+				Value: 10 * time.Second,
+				Usage: "Time to wait between polling rounds.",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if c.String("bucket") == "" {
+				return cli.NewExitError("bucket must be specified.", 1)
+			}
+			if err := validateQueryExecutionFlags(c); err != nil {
+				return err
+			}
+			if c.NArg() == 0 {
+				return cli.NewExitError("DATABASE and TABLE must be specified.", 1)
+			}
+			if len(strings.Split(c.Args().First(), ".")) != 2 {
+				return cli.NewExitError("[DATABASE].[TABLE] must contain `.`.", 1)
+			}
+			if c.String("partition-pattern") == "" {
+				return cli.NewExitError("partition-pattern must be specified.", 1)
+			}
+			switch c.String("polling-method") {
+			case "sqs":
+				if c.String("sqs-queue-url") == "" {
+					return cli.NewExitError("sqs-queue-url must be specified unless --polling-method=list.", 1)
+				}
+			case "list":
+			default:
+				return cli.NewExitError("polling-method must be one of `sqs`, `list`.", 1)
+			}
+			return nil
+		},
+	},
+	{
+		Name:   "workgroup",
+		Usage:  "Manage Athena workgroups",
+		Subcommands: []cli.Command{
+			{
+				Name:   "ls",
+				Usage:  "List workgroups",
+				Action: cmd.WorkgroupLs,
+			},
+			{
+				Name:   "show",
+				Usage:  "Show a workgroup's configuration",
+				Action: cmd.WorkgroupShow,
+				ArgsUsage:   "NAME",
+				Before: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return cli.NewExitError("NAME must be specified.", 1)
+					}
+					return nil
+				},
+			},
+			{
+				Name:   "mk",
+				Usage:  "Create a workgroup",
+				Action: cmd.WorkgroupMk,
+				ArgsUsage:   "NAME",
+				Flags: []cli.Flag{
+					cli.Int64Flag{
+						Name: "bytes-scanned-cutoff",
+						Usage: "Per-query data usage control, in bytes. Queries that would exceed this are cancelled.",
+					},
+				},
+				Before: func(c *cli.Context) error {
+					if c.NArg() == 0 {
+						return cli.NewExitError("NAME must be specified.", 1)
+					}
+					return nil
+				},
+			},
+		},
+	},
 }
 
 func Run() int {
@@ -206,3 +644,12 @@ func msg(err error) int {
 	}
 	return 0
 }
+
+func validateOutputFormat(c *cli.Context) error {
+	switch c.String("output") {
+	case "csv", "json", "tsv", "table":
+		return nil
+	default:
+		return cli.NewExitError("output must be one of `csv`, `json`, `tsv`, `table`.", 1)
+	}
+}
@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// newSession builds the AWS session shared by subcommands, honoring the
+// usual env vars and ~/.aws/config via the default credential chain.
+func newSession() (*session.Session, error) {
+	return session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+}
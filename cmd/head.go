@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/urfave/cli"
+
+	"github.com/mia-0032/aq-go/result"
+)
+
+// Head prints the first max_rows rows of DATABASE.TABLE, streaming pages
+// from Athena directly so it never has to pull down a full result set.
+func Head(c *cli.Context) error {
+	parts := strings.SplitN(c.Args().First(), ".", 2)
+	database, table := parts[0], parts[1]
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	queryExecutionID, _, statementType, err := startQuery(sess, c.String("bucket"), c.String("object_prefix"), queryOptionsFromContext(c),
+		fmt.Sprintf("SELECT * FROM %s.%s", database, table))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	maxRows := c.Int("max_rows")
+	printed := 0
+	streamer := result.NewResultStreamer(sess, queryExecutionID, statementType)
+
+	err = streamer.ForEachPage(func(rows []*athena.Row) error {
+		for _, row := range rows {
+			if printed >= maxRows {
+				return result.Stop
+			}
+			fmt.Println(strings.Join(rowValues(row), "\t"))
+			printed++
+		}
+		return nil
+	})
+	if err != nil && err != result.Stop {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/mia-0032/aq-go/history"
+)
+
+// History lists past query executions recorded locally by `aq query`.
+func History(c *cli.Context) error {
+	entries, err := history.List()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if since := c.String("since"); since != "" {
+		threshold, err := time.Parse("2006-01-02", since)
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		entries = filterEntries(entries, func(e history.Entry) bool {
+			return !e.Timestamp.Before(threshold)
+		})
+	}
+	if c.Bool("failed-only") {
+		entries = filterEntries(entries, func(e history.Entry) bool {
+			return e.Failed
+		})
+	}
+
+	limit := c.Int("limit")
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	for _, e := range entries {
+		status := "SUCCEEDED"
+		if e.Failed {
+			status = "FAILED"
+		}
+		fmt.Printf("%s\t%s\t%s\t%d bytes\t%.2fs\t%s\n",
+			e.QueryExecutionID, status, e.Timestamp.Format(time.RFC3339), e.BytesScanned, e.WallTimeSeconds, e.SQL)
+	}
+
+	return nil
+}
+
+// HistoryRerun re-executes the query recorded under the given
+// QueryExecutionId.
+func HistoryRerun(c *cli.Context) error {
+	id := c.Args().First()
+	entry, ok := history.Find(id)
+	if !ok {
+		return cli.NewExitError("no history entry found for "+id, 1)
+	}
+	return runAndRecord(c, entry.SQL)
+}
+
+func filterEntries(entries []history.Entry, keep func(history.Entry) bool) []history.Entry {
+	var filtered []history.Entry
+	for _, e := range entries {
+		if keep(e) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
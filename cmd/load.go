@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// Load creates an external table over SOURCE, described by SCHEMA, and
+// registers it as DATABASE.TABLE.
+func Load(c *cli.Context) error {
+	parts := strings.SplitN(c.Args().First(), ".", 2)
+	database, table := parts[0], parts[1]
+	source := c.Args().Get(1)
+	schema := c.Args().Get(2)
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	ddl, err := buildCreateTableDDL(database, table, source, schema, c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if _, err := runAthenaQuery(sess, c.String("bucket"), c.String("object_prefix"), queryOptionsFromContext(c), ddl); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+// buildCreateTableDDL renders the `CREATE EXTERNAL TABLE` statement for the
+// requested source_format, including the ROW FORMAT/STORED AS/SERDEPROPERTIES
+// clauses specific to each format.
+func buildCreateTableDDL(database, table, source, schema string, c *cli.Context) (string, error) {
+	columns, err := columnDefinitions(schema)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE EXTERNAL TABLE %s.%s (%s)\n", database, table, strings.Join(columns, ", "))
+
+	if partitioning := c.String("partitioning"); partitioning != "" {
+		partitionColumns, err := columnDefinitions(partitioning)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "PARTITIONED BY (%s)\n", strings.Join(partitionColumns, ", "))
+	}
+
+	rowFormat, err := rowFormatClause(c)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(rowFormat)
+
+	fmt.Fprintf(&b, "LOCATION '%s'\n", source)
+
+	var tblProperties []string
+	if compression := c.String("compression"); compression != "" {
+		tblProperties = append(tblProperties, fmt.Sprintf("'compressionType'='%s'", compression))
+	}
+	if skipHeaderRows := c.Int("skip_header_rows"); skipHeaderRows > 0 {
+		tblProperties = append(tblProperties, fmt.Sprintf("'skip.header.line.count'='%d'", skipHeaderRows))
+	}
+	if len(tblProperties) > 0 {
+		fmt.Fprintf(&b, "TBLPROPERTIES (%s)\n", strings.Join(tblProperties, ", "))
+	}
+
+	return b.String(), nil
+}
+
+func rowFormatClause(c *cli.Context) (string, error) {
+	switch strings.ToUpper(c.String("source_format")) {
+	case "NEWLINE_DELIMITED_JSON":
+		return "ROW FORMAT SERDE 'org.openx.data.jsonserde.JsonSerDe'\nSTORED AS TEXTFILE\n", nil
+	case "CSV", "TSV":
+		delimiter := c.String("field_delimiter")
+		if delimiter == "" {
+			if strings.ToUpper(c.String("source_format")) == "TSV" {
+				delimiter = "\\t"
+			} else {
+				delimiter = ","
+			}
+		}
+		quote := c.String("quote_char")
+		if quote == "" {
+			quote = "\""
+		}
+		return fmt.Sprintf("ROW FORMAT SERDE 'org.apache.hadoop.hive.serde2.OpenCSVSerde'\n"+
+			"WITH SERDEPROPERTIES ('separatorChar'='%s', 'quoteChar'='%s')\n"+
+			"STORED AS TEXTFILE\n", delimiter, quote), nil
+	case "PARQUET":
+		return "STORED AS PARQUET\n", nil
+	case "ORC":
+		return "STORED AS ORC\n", nil
+	case "AVRO":
+		return "ROW FORMAT SERDE 'org.apache.hadoop.hive.serde2.avro.AvroSerDe'\nSTORED AS AVRO\n", nil
+	default:
+		return "", fmt.Errorf("unsupported source_format: %s", c.String("source_format"))
+	}
+}
+
+// columnDefinitions converts a bq-style "key1:type1,key2:type2" schema
+// string into a slice of "key1 type1" Hive column definitions.
+func columnDefinitions(schema string) ([]string, error) {
+	var columns []string
+	for _, field := range strings.Split(schema, ",") {
+		kv := strings.SplitN(field, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid schema field: %s", field)
+		}
+		columns = append(columns, fmt.Sprintf("%s %s", kv[0], kv[1]))
+	}
+	return columns, nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli"
+)
+
+func loadTestContext(t *testing.T, flags map[string]string) *cli.Context {
+	t.Helper()
+	set := flag.NewFlagSet("test", 0)
+	set.String("source_format", "", "")
+	set.String("field_delimiter", "", "")
+	set.String("quote_char", "", "")
+	set.Int("skip_header_rows", 0, "")
+	set.String("partitioning", "", "")
+	set.String("compression", "", "")
+	for name, value := range flags {
+		if err := set.Set(name, value); err != nil {
+			t.Fatalf("set %s=%s: %v", name, value, err)
+		}
+	}
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func TestRowFormatClauseCSV(t *testing.T) {
+	c := loadTestContext(t, map[string]string{"source_format": "csv"})
+	clause, err := rowFormatClause(c)
+	if err != nil {
+		t.Fatalf("rowFormatClause: %v", err)
+	}
+	if !strings.Contains(clause, "OpenCSVSerde") || !strings.Contains(clause, "separatorChar'=','") {
+		t.Errorf("unexpected CSV clause: %q", clause)
+	}
+}
+
+func TestRowFormatClauseTSVDefaultsTabDelimiter(t *testing.T) {
+	c := loadTestContext(t, map[string]string{"source_format": "tsv"})
+	clause, err := rowFormatClause(c)
+	if err != nil {
+		t.Fatalf("rowFormatClause: %v", err)
+	}
+	if !strings.Contains(clause, `separatorChar'='\t'`) {
+		t.Errorf("expected tab delimiter in TSV clause, got %q", clause)
+	}
+}
+
+func TestRowFormatClauseUnsupportedFormat(t *testing.T) {
+	c := loadTestContext(t, map[string]string{"source_format": "xml"})
+	if _, err := rowFormatClause(c); err == nil {
+		t.Fatal("expected error for unsupported source_format, got nil")
+	}
+}
+
+func TestBuildCreateTableDDLParquet(t *testing.T) {
+	c := loadTestContext(t, map[string]string{"source_format": "parquet"})
+	ddl, err := buildCreateTableDDL("db", "tbl", "s3://bucket/prefix/", "col1:string,col2:int", c)
+	if err != nil {
+		t.Fatalf("buildCreateTableDDL: %v", err)
+	}
+	for _, want := range []string{
+		"CREATE EXTERNAL TABLE db.tbl (col1 string, col2 int)",
+		"STORED AS PARQUET",
+		"LOCATION 's3://bucket/prefix/'",
+	} {
+		if !strings.Contains(ddl, want) {
+			t.Errorf("ddl missing %q:\n%s", want, ddl)
+		}
+	}
+}
+
+func TestBuildCreateTableDDLSkipHeaderRowsIsATableProperty(t *testing.T) {
+	c := loadTestContext(t, map[string]string{"source_format": "csv", "skip_header_rows": "1"})
+	ddl, err := buildCreateTableDDL("db", "tbl", "s3://bucket/prefix/", "col1:string", c)
+	if err != nil {
+		t.Fatalf("buildCreateTableDDL: %v", err)
+	}
+	if !strings.Contains(ddl, "TBLPROPERTIES ('skip.header.line.count'='1')") {
+		t.Errorf("expected skip.header.line.count in TBLPROPERTIES, got:\n%s", ddl)
+	}
+
+	serdeProps := ddl[strings.Index(ddl, "SERDEPROPERTIES"):strings.Index(ddl, "STORED AS")]
+	if strings.Contains(serdeProps, "skip.header.line.count") {
+		t.Errorf("skip.header.line.count must not live in SERDEPROPERTIES:\n%s", serdeProps)
+	}
+}
+
+func TestBuildCreateTableDDLMalformedSchema(t *testing.T) {
+	c := loadTestContext(t, map[string]string{"source_format": "parquet"})
+	if _, err := buildCreateTableDDL("db", "tbl", "s3://bucket/prefix/", "col1string", c); err == nil {
+		t.Fatal("expected error for schema field missing ':', got nil")
+	}
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/urfave/cli"
+)
+
+// Ls lists databases, or the tables within DATABASE if given.
+func Ls(c *cli.Context) error {
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	client := glue.New(sess)
+
+	if database := c.Args().First(); database != "" {
+		return client.GetTablesPages(&glue.GetTablesInput{
+			DatabaseName: aws.String(database),
+		}, func(page *glue.GetTablesOutput, lastPage bool) bool {
+			for _, table := range page.TableList {
+				fmt.Println(aws.StringValue(table.Name))
+			}
+			return !lastPage
+		})
+	}
+
+	return client.GetDatabasesPages(&glue.GetDatabasesInput{},
+		func(page *glue.GetDatabasesOutput, lastPage bool) bool {
+			for _, database := range page.DatabaseList {
+				fmt.Println(aws.StringValue(database.Name))
+			}
+			return !lastPage
+		})
+}
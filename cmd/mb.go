@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/urfave/cli"
+)
+
+// Mb creates the S3 bucket used to store Athena query results.
+func Mb(c *cli.Context) error {
+	bucket := c.Args().First()
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	client := s3.New(sess)
+
+	input := &s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	}
+	if region := c.String("region"); region != "" {
+		input.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(region),
+		}
+	}
+	if acl := c.String("acl"); acl != "" {
+		input.ACL = aws.String(acl)
+	}
+
+	if _, err := client.CreateBucket(input); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if c.Bool("versioning") {
+		_, err := client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+			Bucket: aws.String(bucket),
+			VersioningConfiguration: &s3.VersioningConfiguration{
+				Status: aws.String(s3.BucketVersioningStatusEnabled),
+			},
+		})
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+// Mk creates DATABASE.
+func Mk(c *cli.Context) error {
+	database := c.Args().First()
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	ddl := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", database)
+	if _, err := runAthenaQuery(sess, c.String("bucket"), c.String("object_prefix"), queryOptionsFromContext(c), ddl); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
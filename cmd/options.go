@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/urfave/cli"
+)
+
+// queryOptions carries the workgroup, encryption, and result-reuse
+// settings that every query-running command threads through to
+// StartQueryExecution.
+type queryOptions struct {
+	WorkGroup                string
+	Encryption                string
+	KMSKey                    string
+	ResultReuseMaxAgeMinutes  int64
+}
+
+func queryOptionsFromContext(c *cli.Context) queryOptions {
+	return queryOptions{
+		WorkGroup:               c.String("workgroup"),
+		Encryption:               c.String("encryption"),
+		KMSKey:                   c.String("kms-key"),
+		ResultReuseMaxAgeMinutes: c.Int64("result-reuse-max-age"),
+	}
+}
+
+// apply sets the workgroup, encryption, and result-reuse fields on input,
+// leaving anything already set (such as OutputLocation) untouched.
+func (o queryOptions) apply(input *athena.StartQueryExecutionInput) {
+	if o.WorkGroup != "" {
+		input.WorkGroup = aws.String(o.WorkGroup)
+	}
+	if o.Encryption != "" {
+		if input.ResultConfiguration == nil {
+			input.ResultConfiguration = &athena.ResultConfiguration{}
+		}
+		input.ResultConfiguration.EncryptionConfiguration = &athena.EncryptionConfiguration{
+			EncryptionOption: aws.String(o.Encryption),
+		}
+		if o.KMSKey != "" {
+			input.ResultConfiguration.EncryptionConfiguration.KmsKey = aws.String(o.KMSKey)
+		}
+	}
+	if o.ResultReuseMaxAgeMinutes > 0 {
+		input.ResultReuseConfiguration = &athena.ResultReuseConfiguration{
+			ResultReuseByAgeConfiguration: &athena.ResultReuseByAgeConfiguration{
+				Enabled:         aws.Bool(true),
+				MaxAgeInMinutes: aws.Int64(o.ResultReuseMaxAgeMinutes),
+			},
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+)
+
+func TestQueryOptionsApplyNoneSet(t *testing.T) {
+	input := &athena.StartQueryExecutionInput{}
+	queryOptions{}.apply(input)
+
+	if input.WorkGroup != nil || input.ResultConfiguration != nil || input.ResultReuseConfiguration != nil {
+		t.Errorf("expected no fields set on input, got %+v", input)
+	}
+}
+
+func TestQueryOptionsApplyWorkGroup(t *testing.T) {
+	input := &athena.StartQueryExecutionInput{}
+	queryOptions{WorkGroup: "primary"}.apply(input)
+
+	if aws.StringValue(input.WorkGroup) != "primary" {
+		t.Errorf("WorkGroup = %q, want primary", aws.StringValue(input.WorkGroup))
+	}
+}
+
+func TestQueryOptionsApplyEncryptionWithoutKMSKey(t *testing.T) {
+	input := &athena.StartQueryExecutionInput{}
+	queryOptions{Encryption: "SSE_S3"}.apply(input)
+
+	if input.ResultConfiguration == nil || input.ResultConfiguration.EncryptionConfiguration == nil {
+		t.Fatal("expected EncryptionConfiguration to be set")
+	}
+	if aws.StringValue(input.ResultConfiguration.EncryptionConfiguration.EncryptionOption) != "SSE_S3" {
+		t.Errorf("EncryptionOption = %q, want SSE_S3", aws.StringValue(input.ResultConfiguration.EncryptionConfiguration.EncryptionOption))
+	}
+	if input.ResultConfiguration.EncryptionConfiguration.KmsKey != nil {
+		t.Errorf("expected no KmsKey for SSE_S3, got %q", aws.StringValue(input.ResultConfiguration.EncryptionConfiguration.KmsKey))
+	}
+}
+
+func TestQueryOptionsApplyEncryptionWithKMSKey(t *testing.T) {
+	input := &athena.StartQueryExecutionInput{}
+	queryOptions{Encryption: "SSE_KMS", KMSKey: "arn:aws:kms:us-east-1:123456789012:key/abc"}.apply(input)
+
+	if input.ResultConfiguration == nil || input.ResultConfiguration.EncryptionConfiguration == nil {
+		t.Fatal("expected EncryptionConfiguration to be set")
+	}
+	if got := aws.StringValue(input.ResultConfiguration.EncryptionConfiguration.KmsKey); got != "arn:aws:kms:us-east-1:123456789012:key/abc" {
+		t.Errorf("KmsKey = %q", got)
+	}
+}
+
+func TestQueryOptionsApplyPreservesExistingResultConfiguration(t *testing.T) {
+	input := &athena.StartQueryExecutionInput{
+		ResultConfiguration: &athena.ResultConfiguration{OutputLocation: aws.String("s3://bucket/prefix/")},
+	}
+	queryOptions{Encryption: "SSE_S3"}.apply(input)
+
+	if aws.StringValue(input.ResultConfiguration.OutputLocation) != "s3://bucket/prefix/" {
+		t.Errorf("expected existing OutputLocation to be preserved, got %q", aws.StringValue(input.ResultConfiguration.OutputLocation))
+	}
+}
+
+func TestQueryOptionsApplyResultReuseMaxAge(t *testing.T) {
+	input := &athena.StartQueryExecutionInput{}
+	queryOptions{ResultReuseMaxAgeMinutes: 60}.apply(input)
+
+	if input.ResultReuseConfiguration == nil || input.ResultReuseConfiguration.ResultReuseByAgeConfiguration == nil {
+		t.Fatal("expected ResultReuseByAgeConfiguration to be set")
+	}
+	cfg := input.ResultReuseConfiguration.ResultReuseByAgeConfiguration
+	if !aws.BoolValue(cfg.Enabled) {
+		t.Error("expected Enabled to be true")
+	}
+	if aws.Int64Value(cfg.MaxAgeInMinutes) != 60 {
+		t.Errorf("MaxAgeInMinutes = %d, want 60", aws.Int64Value(cfg.MaxAgeInMinutes))
+	}
+}
+
+func TestQueryOptionsApplyResultReuseZeroIsNoop(t *testing.T) {
+	input := &athena.StartQueryExecutionInput{}
+	queryOptions{ResultReuseMaxAgeMinutes: 0}.apply(input)
+
+	if input.ResultReuseConfiguration != nil {
+		t.Errorf("expected no ResultReuseConfiguration for zero max age, got %+v", input.ResultReuseConfiguration)
+	}
+}
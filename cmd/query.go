@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/urfave/cli"
+
+	"github.com/mia-0032/aq-go/history"
+	"github.com/mia-0032/aq-go/result"
+)
+
+// Query runs the given SQL against Athena, printing the result and
+// recording it to the local query history.
+func Query(c *cli.Context) error {
+	sql := strings.Join(c.Args(), " ")
+	return runAndRecord(c, sql)
+}
+
+func runAndRecord(c *cli.Context, sql string) error {
+	bucket := c.String("bucket")
+	objectPrefix := c.String("object_prefix")
+
+	if limit := c.Int64("cost-estimate"); limit > 0 {
+		if projected := projectedBytesScanned(sql); projected > limit {
+			return cli.NewExitError(fmt.Sprintf(
+				"refusing to run: projected scan of %d bytes exceeds --cost-estimate of %d bytes", projected, limit), 1)
+		}
+	}
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	client := athena.New(sess)
+
+	input := &athena.StartQueryExecutionInput{
+		QueryString: aws.String(sql),
+		ResultConfiguration: &athena.ResultConfiguration{
+			OutputLocation: aws.String(fmt.Sprintf("s3://%s/%s", bucket, objectPrefix)),
+		},
+	}
+	queryOptionsFromContext(c).apply(input)
+
+	start, err := client.StartQueryExecution(input)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	startedAt := time.Now()
+	timeout := time.Duration(c.Int("timeout")) * time.Second
+
+	var execOut *athena.GetQueryExecutionOutput
+	for {
+		execOut, err = client.GetQueryExecution(&athena.GetQueryExecutionInput{
+			QueryExecutionId: start.QueryExecutionId,
+		})
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		state := aws.StringValue(execOut.QueryExecution.Status.State)
+		if state == athena.QueryExecutionStateSucceeded ||
+			state == athena.QueryExecutionStateFailed ||
+			state == athena.QueryExecutionStateCancelled {
+			break
+		}
+		if timeout > 0 && time.Since(startedAt) > timeout {
+			return cli.NewExitError("timed out waiting for query to finish", 1)
+		}
+		time.Sleep(time.Second)
+	}
+
+	entry := history.Entry{
+		QueryExecutionID: aws.StringValue(start.QueryExecutionId),
+		SQL:               sql,
+		ResultLocation:     aws.StringValue(execOut.QueryExecution.ResultConfiguration.OutputLocation),
+		Timestamp:          startedAt,
+		WallTimeSeconds:    time.Since(startedAt).Seconds(),
+	}
+	if stats := execOut.QueryExecution.Statistics; stats != nil {
+		entry.BytesScanned = aws.Int64Value(stats.DataScannedInBytes)
+	}
+
+	state := aws.StringValue(execOut.QueryExecution.Status.State)
+	if state != athena.QueryExecutionStateSucceeded {
+		entry.Failed = true
+		history.Append(entry)
+		return cli.NewExitError(aws.StringValue(execOut.QueryExecution.Status.StateChangeReason), 1)
+	}
+	history.Append(entry)
+
+	out, closeOut, err := outputWriter(c.String("pager"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	defer closeOut()
+
+	writeRow := rowWriter(out, c.String("output"))
+	streamer := result.NewResultStreamer(sess, aws.StringValue(start.QueryExecutionId), aws.StringValue(execOut.QueryExecution.StatementType))
+	if err := streamer.ForEachPage(func(rows []*athena.Row) error {
+		for _, row := range rows {
+			if err := writeRow(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+// outputWriter returns where query results should be written: directly to
+// stdout, or piped through pagerCmd (typically $PAGER) if set.
+func outputWriter(pagerCmd string) (io.Writer, func(), error) {
+	if pagerCmd == "" {
+		return os.Stdout, func() {}, nil
+	}
+
+	cmd := exec.Command("sh", "-c", pagerCmd)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+
+	return stdin, func() {
+		stdin.Close()
+		cmd.Wait()
+	}, nil
+}
+
+// rowWriter renders one athena.Row at a time in the requested format.
+// json emits one JSON array of the row's values per line (JSON Lines) so it
+// can stream.
+func rowWriter(w io.Writer, format string) func(*athena.Row) error {
+	csvWriter := csv.NewWriter(w)
+	if format == "tsv" {
+		csvWriter.Comma = '\t'
+	}
+
+	return func(row *athena.Row) error {
+		values := rowValues(row)
+		switch format {
+		case "json":
+			b, err := json.Marshal(values)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(w, string(b))
+			return err
+		case "csv", "tsv":
+			if err := csvWriter.Write(values); err != nil {
+				return err
+			}
+			csvWriter.Flush()
+			return csvWriter.Error()
+		default: // table
+			_, err := fmt.Fprintln(w, strings.Join(values, "\t"))
+			return err
+		}
+	}
+}
+
+func rowValues(row *athena.Row) []string {
+	var values []string
+	for _, datum := range row.Data {
+		values = append(values, aws.StringValue(datum.VarCharValue))
+	}
+	return values
+}
+
+// projectedBytesScanned estimates how many bytes sql will scan based on
+// the most recent successful run of the identical query text.
+func projectedBytesScanned(sql string) int64 {
+	entries, err := history.List()
+	if err != nil {
+		return 0
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].SQL == sql && !entries[i].Failed {
+			return entries[i].BytesScanned
+		}
+	}
+	return 0
+}
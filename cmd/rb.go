@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/urfave/cli"
+)
+
+// Rb empties and deletes the S3 bucket used to store Athena query results.
+func Rb(c *cli.Context) error {
+	bucket := c.Args().First()
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	client := s3.New(sess)
+
+	if err := emptyBucket(client, bucket); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if _, err := client.DeleteBucket(&s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	}); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+// bucketEmptier is the subset of *s3.S3 that emptyBucket needs, narrowed so
+// tests can supply a fake.
+type bucketEmptier interface {
+	ListObjectVersionsPages(input *s3.ListObjectVersionsInput, fn func(*s3.ListObjectVersionsOutput, bool) bool) error
+	DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
+}
+
+// emptyBucket deletes every object version and delete marker in bucket, so
+// it also empties buckets created with `mb --versioning`; ListObjectsV2
+// alone only sees current versions and would leave noncurrent ones behind,
+// causing the later DeleteBucket call to fail with BucketNotEmpty.
+func emptyBucket(client bucketEmptier, bucket string) error {
+	var deleteErr error
+
+	err := client.ListObjectVersionsPages(&s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+	}, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		var objects []*s3.ObjectIdentifier
+		for _, v := range page.Versions {
+			objects = append(objects, &s3.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+		}
+		for _, m := range page.DeleteMarkers {
+			objects = append(objects, &s3.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
+		}
+		if len(objects) == 0 {
+			return !lastPage
+		}
+		_, deleteErr = client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{Objects: objects},
+		})
+		return deleteErr == nil && !lastPage
+	})
+	if deleteErr != nil {
+		return deleteErr
+	}
+	return err
+}
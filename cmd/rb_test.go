@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+type fakeBucketEmptier struct {
+	pages     [][]*s3.ObjectVersion
+	markers   [][]*s3.DeleteMarkerEntry
+	deleteErr error
+	deleted   []*s3.ObjectIdentifier
+}
+
+func (f *fakeBucketEmptier) ListObjectVersionsPages(input *s3.ListObjectVersionsInput, fn func(*s3.ListObjectVersionsOutput, bool) bool) error {
+	pages := f.pages
+	if pages == nil {
+		pages = make([][]*s3.ObjectVersion, len(f.markers))
+	}
+	for i := range pages {
+		lastPage := i == len(pages)-1
+		var markers []*s3.DeleteMarkerEntry
+		if i < len(f.markers) {
+			markers = f.markers[i]
+		}
+		if !fn(&s3.ListObjectVersionsOutput{Versions: pages[i], DeleteMarkers: markers}, lastPage) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (f *fakeBucketEmptier) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	f.deleted = append(f.deleted, input.Delete.Objects...)
+	return &s3.DeleteObjectsOutput{}, f.deleteErr
+}
+
+func TestEmptyBucketDeletesVersionsAndDeleteMarkers(t *testing.T) {
+	client := &fakeBucketEmptier{
+		pages: [][]*s3.ObjectVersion{
+			{{Key: aws.String("a"), VersionId: aws.String("v1")}},
+		},
+		markers: [][]*s3.DeleteMarkerEntry{
+			{{Key: aws.String("b"), VersionId: aws.String("v2")}},
+		},
+	}
+
+	if err := emptyBucket(client, "bucket"); err != nil {
+		t.Fatalf("emptyBucket: %v", err)
+	}
+
+	if len(client.deleted) != 2 {
+		t.Fatalf("expected 2 objects deleted, got %d: %v", len(client.deleted), client.deleted)
+	}
+}
+
+func TestEmptyBucketPropagatesDeleteObjectsError(t *testing.T) {
+	client := &fakeBucketEmptier{
+		pages: [][]*s3.ObjectVersion{
+			{{Key: aws.String("a"), VersionId: aws.String("v1")}},
+		},
+		deleteErr: errors.New("access denied"),
+	}
+
+	if err := emptyBucket(client, "bucket"); err == nil {
+		t.Fatal("expected DeleteObjects error to propagate, got nil")
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// Rm drops the database or table named NAME.
+func Rm(c *cli.Context) error {
+	name := c.Args().First()
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	var ddl string
+	if parts := strings.SplitN(name, ".", 2); len(parts) == 2 {
+		ddl = fmt.Sprintf("DROP TABLE IF EXISTS %s.%s", parts[0], parts[1])
+	} else {
+		ddl = fmt.Sprintf("DROP DATABASE IF EXISTS %s CASCADE", name)
+	}
+
+	if _, err := runAthenaQuery(sess, c.String("bucket"), c.String("object_prefix"), queryOptionsFromContext(c), ddl); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
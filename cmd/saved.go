@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/mia-0032/aq-go/saved"
+)
+
+// SavedAdd stores NAME SQL as a named, reusable query.
+func SavedAdd(c *cli.Context) error {
+	name := c.Args().First()
+	sql := strings.Join(c.Args().Tail(), " ")
+	if err := saved.Add(name, sql); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	return nil
+}
+
+// SavedRun renders and executes the named saved query, substituting
+// `--param k=v` into its `{{.k}}` placeholders.
+func SavedRun(c *cli.Context) error {
+	name := c.Args().First()
+	q, ok := saved.Find(name)
+	if !ok {
+		return cli.NewExitError("no saved query named "+name, 1)
+	}
+
+	params := map[string]string{}
+	for _, kv := range c.StringSlice("param") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return cli.NewExitError("--param must be in the form key=value", 1)
+		}
+		params[parts[0]] = parts[1]
+	}
+
+	sql, err := saved.Render(q, params)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return runAndRecord(c, sql)
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// tableSummary is the information rendered by `show`, gathered from
+// `SHOW CREATE TABLE`, `SHOW PARTITIONS` and Glue's GetTable.
+type tableSummary struct {
+	Database     string   `json:"database" yaml:"database"`
+	Table        string   `json:"table" yaml:"table"`
+	CreateTable  string   `json:"create_table" yaml:"create_table"`
+	Columns      []string `json:"columns" yaml:"columns"`
+	Partitions   []string `json:"partitions" yaml:"partitions"`
+	Location     string   `json:"location" yaml:"location"`
+	SerDe        string   `json:"serde" yaml:"serde"`
+	RowCount     int64    `json:"row_count_estimate" yaml:"row_count_estimate"`
+	LastModified string   `json:"last_modified" yaml:"last_modified"`
+}
+
+// Show prints schema, partitions and storage metadata for DATABASE.TABLE.
+func Show(c *cli.Context) error {
+	parts := strings.SplitN(c.Args().First(), ".", 2)
+	database, table := parts[0], parts[1]
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	bucket := c.String("bucket")
+	objectPrefix := c.String("object_prefix")
+	opts := queryOptionsFromContext(c)
+
+	createRows, err := runAthenaQuery(sess, bucket, objectPrefix, opts, fmt.Sprintf("SHOW CREATE TABLE %s.%s", database, table))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	partitionsLimit := c.Int("partitions_limit")
+	partitionRows, err := runAthenaQuery(sess, bucket, objectPrefix, opts, fmt.Sprintf("SHOW PARTITIONS %s.%s", database, table))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	var partitions []string
+	for i, row := range partitionRows {
+		if i >= partitionsLimit {
+			break
+		}
+		if len(row) > 0 {
+			partitions = append(partitions, row[0])
+		}
+	}
+
+	glueClient := glue.New(sess)
+	out, err := glueClient.GetTable(&glue.GetTableInput{
+		DatabaseName: aws.String(database),
+		Name:         aws.String(table),
+	})
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	summary := tableSummary{
+		Database:    database,
+		Table:       table,
+		CreateTable: strings.Join(flatten(createRows), "\n"),
+		Columns:     columnsFromTable(out.Table),
+		Partitions:  partitions,
+		Location:    locationFromTable(out.Table),
+		SerDe:       serdeFromTable(out.Table),
+		RowCount:    rowCountEstimate(out.Table),
+	}
+	if out.Table.UpdateTime != nil {
+		summary.LastModified = out.Table.UpdateTime.Format(time.RFC3339)
+	}
+
+	return printSummary(summary, c.String("format"))
+}
+
+func columnsFromTable(table *glue.TableData) []string {
+	if table == nil || table.StorageDescriptor == nil {
+		return nil
+	}
+	var columns []string
+	for _, col := range table.StorageDescriptor.Columns {
+		columns = append(columns, fmt.Sprintf("%s %s", aws.StringValue(col.Name), aws.StringValue(col.Type)))
+	}
+	return columns
+}
+
+func rowCountEstimate(table *glue.TableData) int64 {
+	if table == nil || table.Parameters == nil {
+		return 0
+	}
+	if v, ok := table.Parameters["numRows"]; ok {
+		var n int64
+		fmt.Sscanf(aws.StringValue(v), "%d", &n)
+		return n
+	}
+	return 0
+}
+
+func locationFromTable(table *glue.TableData) string {
+	if table == nil || table.StorageDescriptor == nil {
+		return ""
+	}
+	return aws.StringValue(table.StorageDescriptor.Location)
+}
+
+func serdeFromTable(table *glue.TableData) string {
+	if table == nil || table.StorageDescriptor == nil || table.StorageDescriptor.SerdeInfo == nil {
+		return ""
+	}
+	return aws.StringValue(table.StorageDescriptor.SerdeInfo.SerializationLibrary)
+}
+
+func flatten(rows [][]string) []string {
+	var lines []string
+	for _, row := range rows {
+		lines = append(lines, strings.Join(row, " "))
+	}
+	return lines
+}
+
+func printSummary(summary tableSummary, format string) error {
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		fmt.Println(string(b))
+	case "yaml":
+		b, err := yaml.Marshal(summary)
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		fmt.Print(string(b))
+	default:
+		fmt.Printf("Table: %s.%s\n", summary.Database, summary.Table)
+		fmt.Printf("Location: %s\n", summary.Location)
+		fmt.Printf("SerDe: %s\n", summary.SerDe)
+		fmt.Printf("RowCountEstimate: %d\n", summary.RowCount)
+		fmt.Printf("LastModified: %s\n", summary.LastModified)
+		fmt.Println("Columns:")
+		for _, col := range summary.Columns {
+			fmt.Printf("  %s\n", col)
+		}
+		fmt.Println("Partitions:")
+		for _, p := range summary.Partitions {
+			fmt.Printf("  %s\n", p)
+		}
+	}
+	return nil
+}
+
+// runAthenaQuery executes query synchronously via Athena, storing its
+// result under s3://bucket/objectPrefix, and returns the result rows
+// (header row included) as parsed CSV.
+func runAthenaQuery(sess *session.Session, bucket, objectPrefix string, opts queryOptions, query string) ([][]string, error) {
+	_, outputLocation, _, err := startQuery(sess, bucket, objectPrefix, opts, query)
+	if err != nil {
+		return nil, err
+	}
+	return downloadResult(sess, aws.String(outputLocation))
+}
+
+// startQuery submits query to Athena and blocks until it reaches a
+// terminal state, returning its QueryExecutionId, result location, and
+// StatementType (athena.StatementTypeDml, Ddl, or Utility).
+func startQuery(sess *session.Session, bucket, objectPrefix string, opts queryOptions, query string) (queryExecutionID, outputLocation, statementType string, err error) {
+	client := athena.New(sess)
+
+	input := &athena.StartQueryExecutionInput{
+		QueryString: aws.String(query),
+		ResultConfiguration: &athena.ResultConfiguration{
+			OutputLocation: aws.String(fmt.Sprintf("s3://%s/%s", bucket, objectPrefix)),
+		},
+	}
+	opts.apply(input)
+
+	start, err := client.StartQueryExecution(input)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	for {
+		exec, err := client.GetQueryExecution(&athena.GetQueryExecutionInput{
+			QueryExecutionId: start.QueryExecutionId,
+		})
+		if err != nil {
+			return "", "", "", err
+		}
+		switch aws.StringValue(exec.QueryExecution.Status.State) {
+		case athena.QueryExecutionStateSucceeded:
+			return aws.StringValue(start.QueryExecutionId),
+				aws.StringValue(exec.QueryExecution.ResultConfiguration.OutputLocation),
+				aws.StringValue(exec.QueryExecution.StatementType),
+				nil
+		case athena.QueryExecutionStateFailed, athena.QueryExecutionStateCancelled:
+			return "", "", "", fmt.Errorf("query failed: %s", aws.StringValue(exec.QueryExecution.Status.StateChangeReason))
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func downloadResult(sess *session.Session, location *string) ([][]string, error) {
+	bucket, key, err := splitS3URI(aws.StringValue(location))
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &aws.WriteAtBuffer{}
+	downloader := s3manager.NewDownloader(sess)
+	if _, err := downloader.Download(buf, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return nil, err
+	}
+
+	return csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+}
+
+func splitS3URI(uri string) (bucket, key string, err error) {
+	trimmed := strings.TrimPrefix(uri, "s3://")
+	parts := strings.SplitN(trimmed, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid S3 URI: %s", uri)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/glue"
+)
+
+func TestColumnsFromTable(t *testing.T) {
+	table := &glue.TableData{
+		StorageDescriptor: &glue.StorageDescriptor{
+			Columns: []*glue.Column{
+				{Name: aws.String("id"), Type: aws.String("bigint")},
+				{Name: aws.String("name"), Type: aws.String("string")},
+			},
+		},
+	}
+	got := columnsFromTable(table)
+	want := []string{"id bigint", "name string"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("columnsFromTable = %v, want %v", got, want)
+	}
+}
+
+func TestColumnsFromTableNilStorageDescriptor(t *testing.T) {
+	if got := columnsFromTable(&glue.TableData{}); got != nil {
+		t.Errorf("columnsFromTable = %v, want nil", got)
+	}
+}
+
+func TestRowCountEstimate(t *testing.T) {
+	table := &glue.TableData{Parameters: map[string]*string{"numRows": aws.String("42")}}
+	if got := rowCountEstimate(table); got != 42 {
+		t.Errorf("rowCountEstimate = %d, want 42", got)
+	}
+}
+
+func TestRowCountEstimateMissingParameter(t *testing.T) {
+	if got := rowCountEstimate(&glue.TableData{Parameters: map[string]*string{}}); got != 0 {
+		t.Errorf("rowCountEstimate = %d, want 0", got)
+	}
+}
+
+func TestLocationFromTable(t *testing.T) {
+	table := &glue.TableData{
+		StorageDescriptor: &glue.StorageDescriptor{Location: aws.String("s3://bucket/prefix/")},
+	}
+	if got := locationFromTable(table); got != "s3://bucket/prefix/" {
+		t.Errorf("locationFromTable = %q, want %q", got, "s3://bucket/prefix/")
+	}
+}
+
+func TestSerdeFromTable(t *testing.T) {
+	table := &glue.TableData{
+		StorageDescriptor: &glue.StorageDescriptor{
+			SerdeInfo: &glue.SerDeInfo{SerializationLibrary: aws.String("org.apache.hadoop.hive.serde2.OpenCSVSerde")},
+		},
+	}
+	if got := serdeFromTable(table); got != "org.apache.hadoop.hive.serde2.OpenCSVSerde" {
+		t.Errorf("serdeFromTable = %q, want OpenCSVSerde", got)
+	}
+}
+
+func TestSerdeFromTableNilSerdeInfo(t *testing.T) {
+	table := &glue.TableData{StorageDescriptor: &glue.StorageDescriptor{}}
+	if got := serdeFromTable(table); got != "" {
+		t.Errorf("serdeFromTable = %q, want empty", got)
+	}
+}
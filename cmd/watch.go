@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/urfave/cli"
+)
+
+// Watch continuously registers new partitions on DATABASE.TABLE as objects
+// land in S3, driven either by SQS notifications or by polling the bucket
+// listing directly.
+func Watch(c *cli.Context) error {
+	parts := strings.SplitN(c.Args().First(), ".", 2)
+	database, table := parts[0], parts[1]
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	w := &watcher{
+		sess:              sess,
+		database:          database,
+		table:             table,
+		bucket:            c.String("bucket"),
+		objectPrefix:      c.String("object_prefix"),
+		queryOptions:      queryOptionsFromContext(c),
+		partitionPattern:  c.String("partition-pattern"),
+		batchSize:         c.Int("batch-size"),
+		visibilityTimeout: c.Int64("visibility-timeout"),
+		pollInterval:      c.Duration("poll-interval"),
+	}
+
+	switch c.String("polling-method") {
+	case "list":
+		return w.watchByListing(c.String("bucket"))
+	default:
+		return w.watchBySQS(c.String("sqs-queue-url"))
+	}
+}
+
+type watcher struct {
+	sess              *session.Session
+	database, table   string
+	bucket            string
+	objectPrefix      string
+	queryOptions      queryOptions
+	partitionPattern  string
+	batchSize         int
+	visibilityTimeout int64
+	pollInterval      time.Duration
+}
+
+// watchBySQS long-polls queueURL for S3 ObjectCreated notifications,
+// batching partition adds and acknowledging messages once the batch's DDL
+// has run successfully.
+func (w *watcher) watchBySQS(queueURL string) error {
+	client := sqs.New(w.sess)
+
+	for {
+		out, err := client.ReceiveMessage(&sqs.ReceiveMessageInput{
+			QueueUrl:            awssdk.String(queueURL),
+			MaxNumberOfMessages:  awssdk.Int64(10),
+			WaitTimeSeconds:      awssdk.Int64(20),
+			VisibilityTimeout:    awssdk.Int64(w.visibilityTimeout),
+		})
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+
+		keys := make(map[string]string) // s3 key -> receipt handle
+		for _, msg := range out.Messages {
+			var event events.S3Event
+			if err := unmarshalS3Event(awssdk.StringValue(msg.Body), &event); err != nil {
+				continue
+			}
+			for _, record := range event.Records {
+				keys[record.S3.Object.Key] = awssdk.StringValue(msg.ReceiptHandle)
+			}
+
+			if len(keys) >= w.batchSize {
+				processed, err := w.addPartitionsForKeys(keys)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				w.deleteMessages(client, queueURL, keys, processed)
+				keys = make(map[string]string)
+			}
+		}
+
+		if len(keys) > 0 {
+			processed, err := w.addPartitionsForKeys(keys)
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			w.deleteMessages(client, queueURL, keys, processed)
+		}
+
+		time.Sleep(w.pollInterval)
+	}
+}
+
+// watchByListing is the no-SQS fallback: it lists the bucket sorted by
+// LastModified and remembers the high watermark under objectPrefix so a
+// restart doesn't reprocess everything.
+func (w *watcher) watchByListing(bucket string) error {
+	client := s3.New(w.sess)
+	watermarkKey := w.objectPrefix + "/.watch-watermark"
+
+	for {
+		watermark := w.readWatermark(client, bucket, watermarkKey)
+
+		var objects []*s3.Object
+		err := client.ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: awssdk.String(bucket)},
+			func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+				objects = append(objects, page.Contents...)
+				return !lastPage
+			})
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+
+		sort.Slice(objects, func(i, j int) bool {
+			return objects[i].LastModified.Before(*objects[j].LastModified)
+		})
+
+		keys := make(map[string]string)
+		keyTimes := make(map[string]time.Time)
+		var newWatermark time.Time
+		advanceWatermark := func(processed map[string]bool) {
+			for key := range processed {
+				if t := keyTimes[key]; t.After(newWatermark) {
+					newWatermark = t
+				}
+			}
+		}
+
+		for _, obj := range objects {
+			if !obj.LastModified.After(watermark) {
+				continue
+			}
+			key := awssdk.StringValue(obj.Key)
+			keys[key] = ""
+			keyTimes[key] = *obj.LastModified
+			if len(keys) >= w.batchSize {
+				processed, err := w.addPartitionsForKeys(keys)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				advanceWatermark(processed)
+				keys = make(map[string]string)
+				keyTimes = make(map[string]time.Time)
+			}
+		}
+		if len(keys) > 0 {
+			processed, err := w.addPartitionsForKeys(keys)
+			if err != nil {
+				return cli.NewExitError(err.Error(), 1)
+			}
+			advanceWatermark(processed)
+		}
+		if !newWatermark.IsZero() {
+			w.writeWatermark(client, bucket, watermarkKey, newWatermark)
+		}
+
+		time.Sleep(w.pollInterval)
+	}
+}
+
+func (w *watcher) readWatermark(client *s3.S3, bucket, key string) time.Time {
+	out, err := client.GetObject(&s3.GetObjectInput{Bucket: awssdk.String(bucket), Key: awssdk.String(key)})
+	if err != nil {
+		return time.Time{}
+	}
+	defer out.Body.Close()
+	body, err := ioutil.ReadAll(out.Body)
+	if err != nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(body)))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func (w *watcher) writeWatermark(client *s3.S3, bucket, key string, t time.Time) {
+	client.PutObject(&s3.PutObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+		Body:   strings.NewReader(t.Format(time.RFC3339)),
+	})
+}
+
+// addPartitionsForKeys derives one partition per S3 key from
+// partitionPattern and runs a single batched `ALTER TABLE ... ADD
+// PARTITION` statement against Athena. It returns the subset of keys
+// whose partition was successfully derived and included in that
+// statement; keys that renderPartition rejects are logged and left out
+// of the result. Callers delete only SQS messages that carry a key in
+// the result (a message with several records is deleted if any one of
+// its keys is in it), and the listing watermark moves to the newest
+// LastModified among the keys in the result, so a rejected key older
+// than that is not examined again.
+func (w *watcher) addPartitionsForKeys(keys map[string]string) (map[string]bool, error) {
+	processed := make(map[string]bool)
+	seen := make(map[string]bool)
+	var clauses []string
+	for key := range keys {
+		partition, err := renderPartition(w.partitionPattern, key)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "watch: skipping key %s: %v\n", key, err)
+			continue
+		}
+		processed[key] = true
+		if seen[partition] {
+			continue
+		}
+		seen[partition] = true
+		clauses = append(clauses, fmt.Sprintf("PARTITION (%s) LOCATION 's3://%s/%s'", partition, w.bucket, parentPrefix(key)))
+	}
+	if len(clauses) == 0 {
+		return processed, nil
+	}
+
+	ddl := fmt.Sprintf("ALTER TABLE %s.%s ADD IF NOT EXISTS %s", w.database, w.table, strings.Join(clauses, " "))
+	if _, err := runAthenaQuery(w.sess, w.bucket, w.objectPrefix, w.queryOptions, ddl); err != nil {
+		return nil, err
+	}
+	return processed, nil
+}
+
+func (w *watcher) deleteMessages(client *sqs.SQS, queueURL string, keys map[string]string, processed map[string]bool) {
+	seen := make(map[string]bool)
+	for key, receiptHandle := range keys {
+		if !processed[key] || receiptHandle == "" || seen[receiptHandle] {
+			continue
+		}
+		seen[receiptHandle] = true
+		client.DeleteMessage(&sqs.DeleteMessageInput{
+			QueueUrl:      awssdk.String(queueURL),
+			ReceiptHandle: awssdk.String(receiptHandle),
+		})
+	}
+}
+
+func parentPrefix(key string) string {
+	idx := strings.LastIndex(key, "/")
+	if idx < 0 {
+		return ""
+	}
+	return key[:idx]
+}
+
+var partitionFieldPattern = regexp.MustCompile(`\{(YYYY|MM|DD|HH)\}`)
+
+// renderPartition extracts partition column values from key using a
+// pattern over the S3 key path, e.g. "year={YYYY}/month={MM}/day={DD}",
+// in which each {YYYY}, {MM}, {DD} or {HH} placeholder matches a run of
+// digits at that place in the key.
+func renderPartition(pattern, key string) (string, error) {
+	fields := partitionFieldPattern.FindAllString(pattern, -1)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("partition-pattern has no placeholders")
+	}
+
+	regexSrc := regexp.QuoteMeta(pattern)
+	for _, field := range fields {
+		replacement := `(\d+)`
+		regexSrc = strings.Replace(regexSrc, regexp.QuoteMeta(field), replacement, 1)
+	}
+	re, err := regexp.Compile("^" + regexSrc)
+	if err != nil {
+		return "", err
+	}
+	match := re.FindStringSubmatch(key)
+	if match == nil {
+		return "", fmt.Errorf("key %s does not match partition-pattern", key)
+	}
+
+	columnNames := regexp.MustCompile(`(\w+)=\{`).FindAllStringSubmatch(pattern, -1)
+	var clauses []string
+	for i, name := range columnNames {
+		clauses = append(clauses, fmt.Sprintf("%s='%s'", name[1], match[i+1]))
+	}
+	return strings.Join(clauses, ", "), nil
+}
+
+func unmarshalS3Event(body string, event *events.S3Event) error {
+	return json.Unmarshal([]byte(body), event)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestRenderPartition(t *testing.T) {
+	got, err := renderPartition("year={YYYY}/month={MM}/day={DD}", "year=2026/month=07/day=25/part-0000.json")
+	if err != nil {
+		t.Fatalf("renderPartition: %v", err)
+	}
+	want := "year='2026', month='07', day='25'"
+	if got != want {
+		t.Errorf("renderPartition = %q, want %q", got, want)
+	}
+}
+
+func TestRenderPartitionKeyDoesNotMatchPattern(t *testing.T) {
+	if _, err := renderPartition("year={YYYY}/month={MM}/day={DD}", "unrelated/prefix/part-0000.json"); err == nil {
+		t.Fatal("expected error for key that doesn't match partition-pattern, got nil")
+	}
+}
+
+func TestRenderPartitionNoPlaceholders(t *testing.T) {
+	if _, err := renderPartition("year=2026", "year=2026/part-0000.json"); err == nil {
+		t.Fatal("expected error for pattern with no placeholders, got nil")
+	}
+}
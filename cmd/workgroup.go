@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/urfave/cli"
+)
+
+// WorkgroupLs lists the Athena workgroups available in the account.
+func WorkgroupLs(c *cli.Context) error {
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	client := athena.New(sess)
+
+	return client.ListWorkGroupsPages(&athena.ListWorkGroupsInput{},
+		func(page *athena.ListWorkGroupsOutput, lastPage bool) bool {
+			for _, wg := range page.WorkGroups {
+				fmt.Println(aws.StringValue(wg.Name))
+			}
+			return !lastPage
+		})
+}
+
+// WorkgroupShow prints the configuration of a single workgroup.
+func WorkgroupShow(c *cli.Context) error {
+	name := c.Args().First()
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	client := athena.New(sess)
+
+	out, err := client.GetWorkGroup(&athena.GetWorkGroupInput{WorkGroup: aws.String(name)})
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	wg := out.WorkGroup
+	fmt.Printf("Name: %s\n", aws.StringValue(wg.Name))
+	fmt.Printf("State: %s\n", aws.StringValue(wg.State))
+	if wg.Configuration != nil {
+		if cutoff := wg.Configuration.BytesScannedCutoffPerQuery; cutoff != nil {
+			fmt.Printf("BytesScannedCutoffPerQuery: %d\n", aws.Int64Value(cutoff))
+		}
+		if rc := wg.Configuration.ResultConfiguration; rc != nil {
+			fmt.Printf("OutputLocation: %s\n", aws.StringValue(rc.OutputLocation))
+		}
+	}
+
+	return nil
+}
+
+// WorkgroupMk creates a new Athena workgroup.
+func WorkgroupMk(c *cli.Context) error {
+	name := c.Args().First()
+
+	sess, err := newSession()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	client := athena.New(sess)
+
+	input := &athena.CreateWorkGroupInput{Name: aws.String(name)}
+	if cutoff := c.Int64("bytes-scanned-cutoff"); cutoff > 0 {
+		input.Configuration = &athena.WorkGroupConfiguration{
+			BytesScannedCutoffPerQuery: aws.Int64(cutoff),
+		}
+	}
+
+	if _, err := client.CreateWorkGroup(input); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
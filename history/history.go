@@ -0,0 +1,96 @@
+// Package history persists a local, append-only log of query executions
+// so that `aq history` and `aq history rerun` can work without talking to
+// Athena's (short-lived) query execution list.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Entry is one recorded invocation of `aq query`.
+type Entry struct {
+	QueryExecutionID string    `json:"query_execution_id"`
+	SQL              string    `json:"sql"`
+	BytesScanned     int64     `json:"bytes_scanned"`
+	WallTimeSeconds  float64   `json:"wall_time_seconds"`
+	ResultLocation   string    `json:"result_location"`
+	Failed           bool      `json:"failed"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// Path returns ~/.config/aq/history.jsonl, creating its parent directory
+// if necessary.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".config", "aq")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
+// Append records entry at the end of the history file.
+func Append(entry Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(entry)
+}
+
+// List returns every recorded entry, oldest first.
+func List() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Find returns the entry whose QueryExecutionID matches id.
+func Find(id string) (Entry, bool) {
+	entries, err := List()
+	if err != nil {
+		return Entry{}, false
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].QueryExecutionID == id {
+			return entries[i], true
+		}
+	}
+	return Entry{}, false
+}
@@ -0,0 +1,82 @@
+// Package result streams Athena query results directly from the Athena
+// API, page by page, instead of downloading the result CSV object from S3.
+package result
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/athena"
+)
+
+// resultsPager is the subset of *athena.Athena that ResultStreamer needs,
+// narrowed so tests can supply a fake.
+type resultsPager interface {
+	GetQueryResultsPages(input *athena.GetQueryResultsInput, fn func(*athena.GetQueryResultsOutput, bool) bool) error
+}
+
+// ResultStreamer pages through the results of a finished query execution.
+type ResultStreamer struct {
+	client           resultsPager
+	queryExecutionID string
+	statementType    string
+}
+
+// NewResultStreamer returns a ResultStreamer for the given, already
+// SUCCEEDED, query execution. statementType is the QueryExecution's
+// StatementType (athena.StatementTypeDml, Ddl, or Utility); for DML
+// (SELECT) queries Athena puts a synthetic column-name row at the very
+// start of the result set, which ForEachPage skips.
+func NewResultStreamer(sess *session.Session, queryExecutionID, statementType string) *ResultStreamer {
+	return newResultStreamer(athena.New(sess), queryExecutionID, statementType)
+}
+
+func newResultStreamer(client resultsPager, queryExecutionID, statementType string) *ResultStreamer {
+	return &ResultStreamer{
+		client:           client,
+		queryExecutionID: queryExecutionID,
+		statementType:    statementType,
+	}
+}
+
+// ForEachPage invokes fn with the rows of each result page, in order,
+// stopping as soon as fn returns an error or a non-nil sentinel such as
+// Stop. The header row Athena prepends to DML results is skipped.
+func (s *ResultStreamer) ForEachPage(fn func(rows []*athena.Row) error) error {
+	var callbackErr error
+	firstPage := true
+
+	err := s.client.GetQueryResultsPages(&athena.GetQueryResultsInput{
+		QueryExecutionId: aws.String(s.queryExecutionID),
+	}, func(page *athena.GetQueryResultsOutput, lastPage bool) bool {
+		if page.ResultSet == nil {
+			return !lastPage
+		}
+
+		rows := page.ResultSet.Rows
+		if firstPage {
+			if s.statementType == athena.StatementTypeDml && len(rows) > 0 {
+				rows = rows[1:]
+			}
+			firstPage = false
+		}
+
+		if callbackErr = fn(rows); callbackErr != nil {
+			return false
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+	return callbackErr
+}
+
+// Stop is returned by a ForEachPage callback to end pagination early.
+// ForEachPage hands it back to its caller unchanged, like any other
+// callback error; callers compare the result against Stop to tell an
+// early stop from a failure.
+var Stop = &stopError{}
+
+type stopError struct{}
+
+func (*stopError) Error() string { return "result: stop paging" }
@@ -0,0 +1,121 @@
+package result
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+)
+
+type fakeResultsPager struct {
+	pages [][]*athena.Row
+}
+
+func (f *fakeResultsPager) GetQueryResultsPages(input *athena.GetQueryResultsInput, fn func(*athena.GetQueryResultsOutput, bool) bool) error {
+	for i, rows := range f.pages {
+		lastPage := i == len(f.pages)-1
+		if !fn(&athena.GetQueryResultsOutput{ResultSet: &athena.ResultSet{Rows: rows}}, lastPage) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func row(values ...string) *athena.Row {
+	var data []*athena.Datum
+	for _, v := range values {
+		data = append(data, &athena.Datum{VarCharValue: aws.String(v)})
+	}
+	return &athena.Row{Data: data}
+}
+
+func rowsOf(page []*athena.Row) [][]string {
+	var out [][]string
+	for _, r := range page {
+		out = append(out, rowValues(r))
+	}
+	return out
+}
+
+func rowValues(r *athena.Row) []string {
+	var values []string
+	for _, d := range r.Data {
+		values = append(values, aws.StringValue(d.VarCharValue))
+	}
+	return values
+}
+
+func TestForEachPageSkipsHeaderRowForDML(t *testing.T) {
+	client := &fakeResultsPager{pages: [][]*athena.Row{
+		{row("col1", "col2"), row("a", "1")},
+		{row("b", "2")},
+	}}
+	streamer := newResultStreamer(client, "exec-id", athena.StatementTypeDml)
+
+	var got [][]string
+	if err := streamer.ForEachPage(func(rows []*athena.Row) error {
+		for _, r := range rows {
+			got = append(got, rowValues(r))
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachPage: %v", err)
+	}
+
+	want := [][]string{{"a", "1"}, {"b", "2"}}
+	if len(got) != len(want) || got[0][0] != want[0][0] || got[1][0] != want[1][0] {
+		t.Errorf("ForEachPage rows = %v, want %v", got, want)
+	}
+}
+
+func TestForEachPageKeepsFirstRowForDDL(t *testing.T) {
+	client := &fakeResultsPager{pages: [][]*athena.Row{
+		{row("col1", "col2"), row("a", "1")},
+	}}
+	streamer := newResultStreamer(client, "exec-id", athena.StatementTypeDdl)
+
+	var got [][]string
+	if err := streamer.ForEachPage(func(rows []*athena.Row) error {
+		got = append(got, rowsOf(rows)...)
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEachPage: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected DDL results to keep all rows, got %v", got)
+	}
+}
+
+func TestForEachPageStopEndsPagingAndIsReturned(t *testing.T) {
+	client := &fakeResultsPager{pages: [][]*athena.Row{
+		{row("col1"), row("a")},
+		{row("b")},
+	}}
+	streamer := newResultStreamer(client, "exec-id", athena.StatementTypeDml)
+
+	seen := 0
+	err := streamer.ForEachPage(func(rows []*athena.Row) error {
+		seen += len(rows)
+		return Stop
+	})
+	if err != Stop {
+		t.Fatalf("ForEachPage error = %v, want Stop", err)
+	}
+	if seen != 1 {
+		t.Errorf("expected ForEachPage to stop after the first page's rows, saw %d rows", seen)
+	}
+}
+
+func TestForEachPagePropagatesCallbackError(t *testing.T) {
+	client := &fakeResultsPager{pages: [][]*athena.Row{{row("a")}}}
+	streamer := newResultStreamer(client, "exec-id", athena.StatementTypeDdl)
+
+	wantErr := errors.New("boom")
+	if err := streamer.ForEachPage(func(rows []*athena.Row) error {
+		return wantErr
+	}); err != wantErr {
+		t.Errorf("ForEachPage error = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,120 @@
+// Package saved manages the named-query allow-list stored at
+// ~/.config/aq/saved.yaml, so commonly run queries can be invoked by name
+// instead of retyped.
+package saved
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Query is one named, parameterized SQL statement.
+type Query struct {
+	Name string `yaml:"name"`
+	SQL  string `yaml:"sql"`
+}
+
+// Path returns ~/.config/aq/saved.yaml, creating its parent directory if
+// necessary.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".config", "aq")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "saved.yaml"), nil
+}
+
+// Load returns every saved query.
+func Load() ([]Query, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []Query
+	if err := yaml.Unmarshal(b, &queries); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+func save(queries []Query) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(queries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// Add appends a new named query, replacing any existing query with the
+// same name.
+func Add(name, sql string) error {
+	queries, err := Load()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, q := range queries {
+		if q.Name == name {
+			queries[i].SQL = sql
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		queries = append(queries, Query{Name: name, SQL: sql})
+	}
+
+	return save(queries)
+}
+
+// Find returns the named query, if any.
+func Find(name string) (Query, bool) {
+	queries, err := Load()
+	if err != nil {
+		return Query{}, false
+	}
+	for _, q := range queries {
+		if q.Name == name {
+			return q, true
+		}
+	}
+	return Query{}, false
+}
+
+// Render substitutes `{{.key}}` placeholders in q.SQL with params.
+func Render(q Query, params map[string]string) (string, error) {
+	tmpl, err := template.New(q.Name).Parse(q.SQL)
+	if err != nil {
+		return "", fmt.Errorf("parsing saved query %s: %w", q.Name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("rendering saved query %s: %w", q.Name, err)
+	}
+	return buf.String(), nil
+}
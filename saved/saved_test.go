@@ -0,0 +1,22 @@
+package saved
+
+import "testing"
+
+func TestRenderSubstitutesParams(t *testing.T) {
+	q := Query{Name: "by_date", SQL: "SELECT * FROM t WHERE dt = '{{.date}}'"}
+	got, err := Render(q, map[string]string{"date": "2026-07-25"})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	want := "SELECT * FROM t WHERE dt = '2026-07-25'"
+	if got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+func TestRenderInvalidTemplate(t *testing.T) {
+	q := Query{Name: "broken", SQL: "SELECT * FROM t WHERE dt = '{{.date'"}
+	if _, err := Render(q, map[string]string{"date": "2026-07-25"}); err == nil {
+		t.Fatal("expected error for malformed template, got nil")
+	}
+}